@@ -0,0 +1,20 @@
+package main
+
+import "github.com/open-telemetry/opamp-go/protobufs"
+
+// capabilityNames maps the AgentCapabilities bits the REST API depends on to
+// the names returned in "missing capability" errors, so operators see the
+// same identifier the OpAMP spec uses.
+var capabilityNames = map[protobufs.AgentCapabilities]string{
+	protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnMetrics:              "ReportsOwnMetrics",
+	protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnTraces:               "ReportsOwnTraces",
+	protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnLogs:                 "ReportsOwnLogs",
+	protobufs.AgentCapabilities_AgentCapabilities_AcceptsOtherConnectionSettings: "AcceptsOtherConnectionSettings",
+	protobufs.AgentCapabilities_AgentCapabilities_AcceptsRestartCommand:          "AcceptsRestartCommand",
+}
+
+// hasCapability reports whether capabilities, the bitmask an agent last
+// reported in AgentToServer.capabilities, includes cap.
+func hasCapability(capabilities uint64, cap protobufs.AgentCapabilities) bool {
+	return capabilities&uint64(cap) != 0
+}
@@ -0,0 +1,53 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+)
+
+func TestHasCapability(t *testing.T) {
+	metrics := uint64(protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnMetrics)
+	traces := uint64(protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnTraces)
+	restart := uint64(protobufs.AgentCapabilities_AgentCapabilities_AcceptsRestartCommand)
+
+	tests := []struct {
+		name         string
+		capabilities uint64
+		cap          protobufs.AgentCapabilities
+		want         bool
+	}{
+		{
+			name:         "single bit set and checked",
+			capabilities: metrics,
+			cap:          protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnMetrics,
+			want:         true,
+		},
+		{
+			name:         "bit not set",
+			capabilities: metrics,
+			cap:          protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnTraces,
+			want:         false,
+		},
+		{
+			name:         "checked bit set among others",
+			capabilities: metrics | traces | restart,
+			cap:          protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnTraces,
+			want:         true,
+		},
+		{
+			name:         "zero capabilities never match",
+			capabilities: 0,
+			cap:          protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnMetrics,
+			want:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasCapability(tt.capabilities, tt.cap); got != tt.want {
+				t.Errorf("hasCapability(%b, %v) = %v, want %v", tt.capabilities, tt.cap, got, tt.want)
+			}
+		})
+	}
+}
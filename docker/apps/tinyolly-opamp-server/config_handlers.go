@@ -0,0 +1,197 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ConfigRollbackRequest targets a prior config version by hash or version
+// number, and optionally a single agent; the zero value of InstanceID
+// targets every connected agent, matching POST /config.
+type ConfigRollbackRequest struct {
+	Hash       string `json:"hash,omitempty"`
+	Version    int    `json:"version,omitempty"`
+	InstanceID string `json:"instance_id,omitempty"`
+}
+
+// handleConfigHistory serves GET /config/history, returning every recorded
+// config version oldest first.
+func (s *OpAMPServer) handleConfigHistory(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(s.configStore.History())
+}
+
+// handleConfigByHash serves GET /config/{hash}, returning the full config
+// version recorded under that SHA-256 hash.
+func (s *OpAMPServer) handleConfigByHash(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	hash := strings.TrimPrefix(r.URL.Path, "/config/")
+	if hash == "" {
+		http.Error(w, "Config hash is required", http.StatusBadRequest)
+		return
+	}
+
+	version, ok := s.configStore.ByHash(hash)
+	if !ok {
+		http.Error(w, "Config version not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(version)
+}
+
+// handleConfigRollback serves POST /config/rollback, queueing a previously
+// recorded config version as the pending config for all or selected agents.
+func (s *OpAMPServer) handleConfigRollback(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req ConfigRollbackRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var version *ConfigVersion
+	var ok bool
+	switch {
+	case req.Hash != "":
+		version, ok = s.configStore.ByHash(req.Hash)
+	case req.Version != 0:
+		version, ok = s.configStore.ByVersion(req.Version)
+	default:
+		http.Error(w, "Either hash or version is required", http.StatusBadRequest)
+		return
+	}
+	if !ok {
+		http.Error(w, "Config version not found", http.StatusNotFound)
+		return
+	}
+
+	s.setCurrentConfig(version.Config)
+	affectedIDs := s.queueConfigForAgents(version.Config, req.InstanceID)
+
+	response := ConfigUpdateResponse{
+		Status:      "pending",
+		Message:     "Config rollback queued",
+		Hash:        version.Hash,
+		Version:     version.Version,
+		AffectedIDs: affectedIDs,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// ConfigRetryRequest asks the server to re-deliver a config to an agent whose
+// last RemoteConfigStatus was FAILED. Policy selects what gets queued:
+// "retry" (the default) re-sends the same config that failed, while "revert"
+// queues the agent's last known-good config instead.
+type ConfigRetryRequest struct {
+	InstanceID string `json:"instance_id"`
+	Policy     string `json:"policy,omitempty"`
+}
+
+// handleConfigRetry serves POST /config/retry, recovering an agent stuck on
+// a FAILED config by either retrying it or reverting to the last config the
+// agent confirmed it applied.
+func (s *OpAMPServer) handleConfigRetry(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req ConfigRetryRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.InstanceID == "" {
+		http.Error(w, "instance_id is required", http.StatusBadRequest)
+		return
+	}
+
+	s.agentsMu.RLock()
+	agent, exists := s.agents[req.InstanceID]
+	s.agentsMu.RUnlock()
+	if !exists {
+		http.Error(w, "Agent not found", http.StatusNotFound)
+		return
+	}
+
+	policy := req.Policy
+	if policy == "" {
+		policy = "retry"
+	}
+
+	var config string
+	switch policy {
+	case "retry":
+		config = agent.lastSentConfig
+		if config == "" {
+			http.Error(w, "Agent has no previously sent config to retry", http.StatusConflict)
+			return
+		}
+	case "revert":
+		config = agent.lastGoodConfig
+		if config == "" {
+			http.Error(w, "Agent has no known-good config to revert to", http.StatusConflict)
+			return
+		}
+	default:
+		http.Error(w, "Invalid policy: must be retry or revert", http.StatusBadRequest)
+		return
+	}
+
+	affectedIDs := s.queueConfigForAgents(config, req.InstanceID)
+
+	response := ConfigUpdateResponse{
+		Status:      "pending",
+		Message:     fmt.Sprintf("Config %s queued for agent %s", policy, req.InstanceID),
+		Hash:        hashConfig(config),
+		AffectedIDs: affectedIDs,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(response)
+}
+
+// ConfigValidateRequest carries a candidate config for /config/validate to
+// check without queueing it to any agent.
+type ConfigValidateRequest struct {
+	Config string `json:"config"`
+}
+
+// handleValidateConfig serves POST /config/validate, checking a candidate
+// config's structure without queueing it, so the UI can validate a config as
+// it's edited.
+func (s *OpAMPServer) handleValidateConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req ConfigValidateRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(validateConfig(req.Config))
+}
@@ -0,0 +1,104 @@
+package main
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"sync"
+	"time"
+)
+
+// ConfigVersion is one entry in the config history: a specific config body
+// plus the metadata needed to identify and roll back to it.
+type ConfigVersion struct {
+	Hash        string    `json:"hash"`
+	Version     int       `json:"version"`
+	Config      string    `json:"config"`
+	Author      string    `json:"author,omitempty"`
+	Description string    `json:"description,omitempty"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// ConfigStore keeps an ordered, append-only history of collector configs so
+// the server can detect no-op updates, report which version an agent is
+// actually running, and roll back to a prior version.
+type ConfigStore struct {
+	mu      sync.RWMutex
+	history []*ConfigVersion
+	byHash  map[string]*ConfigVersion
+}
+
+// NewConfigStore creates an empty config history.
+func NewConfigStore() *ConfigStore {
+	return &ConfigStore{byHash: make(map[string]*ConfigVersion)}
+}
+
+// hashConfig returns the SHA-256 hex digest of a config body. This is the
+// same value used as the OpAMP ConfigHash, so agents can short-circuit
+// delivery of a config they're already running.
+func hashConfig(config string) string {
+	sum := sha256.Sum256([]byte(config))
+	return hex.EncodeToString(sum[:])
+}
+
+// Add records a new config version. If it's identical to the current latest
+// version, the existing version is returned instead of creating a duplicate
+// history entry.
+func (c *ConfigStore) Add(config, author, description string) *ConfigVersion {
+	hash := hashConfig(config)
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.history) > 0 && c.history[len(c.history)-1].Hash == hash {
+		return c.history[len(c.history)-1]
+	}
+
+	version := &ConfigVersion{
+		Hash:        hash,
+		Version:     len(c.history) + 1,
+		Config:      config,
+		Author:      author,
+		Description: description,
+		CreatedAt:   time.Now(),
+	}
+	c.history = append(c.history, version)
+	c.byHash[hash] = version
+	return version
+}
+
+// Latest returns the most recently added config version, or nil if none exists.
+func (c *ConfigStore) Latest() *ConfigVersion {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if len(c.history) == 0 {
+		return nil
+	}
+	return c.history[len(c.history)-1]
+}
+
+// History returns every recorded config version, oldest first.
+func (c *ConfigStore) History() []*ConfigVersion {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	out := make([]*ConfigVersion, len(c.history))
+	copy(out, c.history)
+	return out
+}
+
+// ByHash looks up a config version by its SHA-256 hex hash.
+func (c *ConfigStore) ByHash(hash string) (*ConfigVersion, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	v, ok := c.byHash[hash]
+	return v, ok
+}
+
+// ByVersion looks up a config version by its 1-based sequence number.
+func (c *ConfigStore) ByVersion(version int) (*ConfigVersion, bool) {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	if version < 1 || version > len(c.history) {
+		return nil, false
+	}
+	return c.history[version-1], true
+}
@@ -0,0 +1,97 @@
+package main
+
+import "testing"
+
+func TestHashConfig(t *testing.T) {
+	a := hashConfig("receivers:\n  otlp:\n")
+	b := hashConfig("receivers:\n  otlp:\n")
+	if a != b {
+		t.Errorf("hashConfig is not deterministic: %q != %q", a, b)
+	}
+
+	if c := hashConfig("something else"); c == a {
+		t.Errorf("hashConfig(%q) and hashConfig(%q) collided: %q", "receivers:\n  otlp:\n", "something else", c)
+	}
+}
+
+func TestConfigStoreAdd(t *testing.T) {
+	store := NewConfigStore()
+
+	v1 := store.Add("config-a", "alice", "first")
+	if v1.Version != 1 {
+		t.Fatalf("first Add: Version = %d, want 1", v1.Version)
+	}
+	if v1.Hash != hashConfig("config-a") {
+		t.Errorf("first Add: Hash = %q, want %q", v1.Hash, hashConfig("config-a"))
+	}
+
+	v2 := store.Add("config-b", "bob", "second")
+	if v2.Version != 2 {
+		t.Fatalf("second Add (different config): Version = %d, want 2", v2.Version)
+	}
+
+	// Re-adding the same config as the current latest must not create a new
+	// history entry.
+	v3 := store.Add("config-b", "carol", "duplicate")
+	if v3 != v2 {
+		t.Errorf("Add with unchanged config returned a new version: got %+v, want the existing %+v", v3, v2)
+	}
+	if got := len(store.History()); got != 2 {
+		t.Errorf("len(History()) = %d after a no-op Add, want 2", got)
+	}
+
+	// Re-adding an older (non-latest) config is a new entry, not a dedup.
+	v4 := store.Add("config-a", "dave", "reintroduced")
+	if v4.Version != 3 {
+		t.Errorf("Add of a non-latest-but-seen-before config: Version = %d, want 3", v4.Version)
+	}
+}
+
+func TestConfigStoreByHashAndByVersion(t *testing.T) {
+	store := NewConfigStore()
+	v1 := store.Add("config-a", "alice", "first")
+	v2 := store.Add("config-b", "bob", "second")
+
+	if got, ok := store.ByHash(v1.Hash); !ok || got != v1 {
+		t.Errorf("ByHash(%q) = %v, %v; want %v, true", v1.Hash, got, ok, v1)
+	}
+	if _, ok := store.ByHash("does-not-exist"); ok {
+		t.Error("ByHash(\"does-not-exist\") reported found")
+	}
+
+	if got, ok := store.ByVersion(2); !ok || got != v2 {
+		t.Errorf("ByVersion(2) = %v, %v; want %v, true", got, ok, v2)
+	}
+	if _, ok := store.ByVersion(0); ok {
+		t.Error("ByVersion(0) reported found")
+	}
+	if _, ok := store.ByVersion(99); ok {
+		t.Error("ByVersion(99) reported found")
+	}
+}
+
+func TestConfigStoreHistoryAndLatest(t *testing.T) {
+	store := NewConfigStore()
+	if got := store.Latest(); got != nil {
+		t.Errorf("Latest() on empty store = %v, want nil", got)
+	}
+	if got := store.History(); len(got) != 0 {
+		t.Errorf("History() on empty store = %v, want empty", got)
+	}
+
+	store.Add("config-a", "alice", "first")
+	v2 := store.Add("config-b", "bob", "second")
+
+	if got := store.Latest(); got != v2 {
+		t.Errorf("Latest() = %v, want %v", got, v2)
+	}
+
+	history := store.History()
+	if len(history) != 2 {
+		t.Fatalf("len(History()) = %d, want 2", len(history))
+	}
+	history[0] = nil // History() must return a copy, not the live slice
+	if store.History()[0] == nil {
+		t.Error("mutating the slice returned by History() affected the store's internal history")
+	}
+}
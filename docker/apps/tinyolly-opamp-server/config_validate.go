@@ -0,0 +1,208 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+)
+
+// ValidationError is one problem found in a collector config, in a shape the
+// TinyOlly UI can render inline in a YAML editor.
+type ValidationError struct {
+	Line    int    `json:"line,omitempty"`
+	Column  int    `json:"column,omitempty"`
+	Path    string `json:"path,omitempty"`
+	Message string `json:"message"`
+}
+
+// ValidationResult is the response body for /config/validate and the
+// rejection body handleUpdateConfig returns for an invalid config.
+type ValidationResult struct {
+	Valid  bool              `json:"valid"`
+	Errors []ValidationError `json:"errors,omitempty"`
+}
+
+var yamlErrorLineRe = regexp.MustCompile(`line (\d+)`)
+
+// validateConfig parses config as YAML, checks that the top-level collector
+// sections are present and that every component a pipeline references is
+// actually defined, and, if OTELCOL_VALIDATE_BIN is set, runs the collector
+// binary's own config validation as a final check.
+func validateConfig(config string) ValidationResult {
+	var root yaml.Node
+	if err := yaml.Unmarshal([]byte(config), &root); err != nil {
+		return ValidationResult{Errors: []ValidationError{parseYAMLSyntaxError(err)}}
+	}
+
+	doc := &root
+	if doc.Kind == yaml.DocumentNode && len(doc.Content) > 0 {
+		doc = doc.Content[0]
+	}
+
+	var errors []ValidationError
+	for _, section := range []string{"receivers", "processors", "exporters", "service"} {
+		if mapValue(doc, section) == nil {
+			errors = append(errors, ValidationError{
+				Path:    section,
+				Message: fmt.Sprintf("missing required top-level section %q", section),
+			})
+		}
+	}
+
+	errors = append(errors, validatePipelineReferences(doc)...)
+
+	dryRunErrors, err := runDryRunValidation(config)
+	if err != nil {
+		log.Printf("Dry-run config validation unavailable: %v", err)
+	}
+	errors = append(errors, dryRunErrors...)
+
+	return ValidationResult{Valid: len(errors) == 0, Errors: errors}
+}
+
+// parseYAMLSyntaxError wraps a YAML parse error as a ValidationError,
+// recovering the line number yaml.v3 embeds in its error message.
+func parseYAMLSyntaxError(err error) ValidationError {
+	verr := ValidationError{Message: err.Error()}
+	if m := yamlErrorLineRe.FindStringSubmatch(err.Error()); m != nil {
+		if line, convErr := strconv.Atoi(m[1]); convErr == nil {
+			verr.Line = line
+		}
+	}
+	return verr
+}
+
+// mapValue returns the value node for key in a YAML mapping node, or nil if
+// node isn't a mapping or doesn't contain key.
+func mapValue(node *yaml.Node, key string) *yaml.Node {
+	if node == nil || node.Kind != yaml.MappingNode {
+		return nil
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		if node.Content[i].Value == key {
+			return node.Content[i+1]
+		}
+	}
+	return nil
+}
+
+// mapKeys returns the set of keys defined in a YAML mapping node.
+func mapKeys(node *yaml.Node) map[string]bool {
+	keys := make(map[string]bool)
+	if node == nil || node.Kind != yaml.MappingNode {
+		return keys
+	}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		keys[node.Content[i].Value] = true
+	}
+	return keys
+}
+
+// validatePipelineReferences confirms that every receiver, processor, and
+// exporter a service pipeline lists is defined under the matching top-level
+// section, and likewise for the extensions service.extensions enables.
+func validatePipelineReferences(doc *yaml.Node) []ValidationError {
+	var errors []ValidationError
+
+	receivers := mapKeys(mapValue(doc, "receivers"))
+	processors := mapKeys(mapValue(doc, "processors"))
+	exporters := mapKeys(mapValue(doc, "exporters"))
+	extensions := mapKeys(mapValue(doc, "extensions"))
+
+	serviceNode := mapValue(doc, "service")
+	if serviceNode == nil {
+		return errors
+	}
+
+	if extRefs := mapValue(serviceNode, "extensions"); extRefs != nil {
+		for _, ref := range extRefs.Content {
+			if !extensions[ref.Value] {
+				errors = append(errors, ValidationError{
+					Line:    ref.Line,
+					Column:  ref.Column,
+					Path:    "service.extensions",
+					Message: fmt.Sprintf("references undefined extension %q", ref.Value),
+				})
+			}
+		}
+	}
+
+	pipelinesNode := mapValue(serviceNode, "pipelines")
+	if pipelinesNode == nil || pipelinesNode.Kind != yaml.MappingNode {
+		return errors
+	}
+
+	componentDefs := map[string]map[string]bool{
+		"receivers":  receivers,
+		"processors": processors,
+		"exporters":  exporters,
+	}
+
+	for i := 0; i+1 < len(pipelinesNode.Content); i += 2 {
+		pipelineName := pipelinesNode.Content[i].Value
+		pipelineNode := pipelinesNode.Content[i+1]
+
+		for component, defined := range componentDefs {
+			refs := mapValue(pipelineNode, component)
+			if refs == nil {
+				continue
+			}
+			for _, ref := range refs.Content {
+				if !defined[ref.Value] {
+					errors = append(errors, ValidationError{
+						Line:    ref.Line,
+						Column:  ref.Column,
+						Path:    fmt.Sprintf("service.pipelines.%s.%s", pipelineName, component),
+						Message: fmt.Sprintf("references undefined %s %q", strings.TrimSuffix(component, "s"), ref.Value),
+					})
+				}
+			}
+		}
+	}
+
+	return errors
+}
+
+// runDryRunValidation runs the collector binary configured via
+// OTELCOL_VALIDATE_BIN against config in "validate" mode, sandboxed to a
+// single subprocess with no access to the real config path. It's a no-op,
+// returning no errors, if the env var isn't set.
+func runDryRunValidation(config string) ([]ValidationError, error) {
+	binPath := os.Getenv("OTELCOL_VALIDATE_BIN")
+	if binPath == "" {
+		return nil, nil
+	}
+
+	tmpFile, err := os.CreateTemp("", "otelcol-validate-*.yaml")
+	if err != nil {
+		return nil, fmt.Errorf("creating temp config for dry-run: %w", err)
+	}
+	defer os.Remove(tmpFile.Name())
+
+	if _, err := tmpFile.WriteString(config); err != nil {
+		tmpFile.Close()
+		return nil, fmt.Errorf("writing temp config for dry-run: %w", err)
+	}
+	if err := tmpFile.Close(); err != nil {
+		return nil, fmt.Errorf("closing temp config for dry-run: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	cmd := exec.CommandContext(ctx, binPath, "validate", "--config", tmpFile.Name())
+	output, err := cmd.CombinedOutput()
+	if err == nil {
+		return nil, nil
+	}
+
+	return []ValidationError{{Message: strings.TrimSpace(string(output))}}, nil
+}
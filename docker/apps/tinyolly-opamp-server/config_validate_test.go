@@ -0,0 +1,126 @@
+package main
+
+import "testing"
+
+const validCollectorConfig = `
+receivers:
+  otlp:
+    protocols:
+      grpc:
+processors:
+  batch:
+exporters:
+  debug:
+extensions:
+  opamp:
+service:
+  extensions: [opamp]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [debug]
+`
+
+func TestValidateConfigValid(t *testing.T) {
+	result := validateConfig(validCollectorConfig)
+	if !result.Valid {
+		t.Fatalf("validateConfig(valid config) = %+v, want Valid = true", result)
+	}
+	if len(result.Errors) != 0 {
+		t.Errorf("validateConfig(valid config) returned errors: %+v", result.Errors)
+	}
+}
+
+func TestValidateConfigSyntaxError(t *testing.T) {
+	result := validateConfig("receivers: [unterminated")
+	if result.Valid {
+		t.Fatal("validateConfig(malformed YAML) = Valid true, want false")
+	}
+	if len(result.Errors) != 1 {
+		t.Fatalf("validateConfig(malformed YAML) returned %d errors, want 1: %+v", len(result.Errors), result.Errors)
+	}
+}
+
+func TestValidateConfigMissingSections(t *testing.T) {
+	result := validateConfig("receivers:\n  otlp:\n")
+	if result.Valid {
+		t.Fatal("validateConfig(missing sections) = Valid true, want false")
+	}
+
+	missing := map[string]bool{}
+	for _, e := range result.Errors {
+		missing[e.Path] = true
+	}
+	for _, section := range []string{"processors", "exporters", "service"} {
+		if !missing[section] {
+			t.Errorf("validateConfig(missing sections) did not report a missing %q section, got %+v", section, result.Errors)
+		}
+	}
+}
+
+func TestValidateConfigUndefinedPipelineReference(t *testing.T) {
+	config := `
+receivers:
+  otlp:
+processors:
+  batch:
+exporters:
+  debug:
+service:
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [does-not-exist]
+`
+	result := validateConfig(config)
+	if result.Valid {
+		t.Fatal("validateConfig(undefined exporter reference) = Valid true, want false")
+	}
+
+	found := false
+	for _, e := range result.Errors {
+		if e.Path == "service.pipelines.traces.exporters" {
+			found = true
+			if e.Line == 0 {
+				t.Errorf("undefined-reference error missing a line number: %+v", e)
+			}
+		}
+	}
+	if !found {
+		t.Errorf("validateConfig didn't report the undefined exporter reference, got %+v", result.Errors)
+	}
+}
+
+func TestValidateConfigUndefinedExtensionReference(t *testing.T) {
+	config := `
+receivers:
+  otlp:
+processors:
+  batch:
+exporters:
+  debug:
+service:
+  extensions: [does-not-exist]
+  pipelines:
+    traces:
+      receivers: [otlp]
+      processors: [batch]
+      exporters: [debug]
+`
+	result := validateConfig(config)
+	if result.Valid {
+		t.Fatal("validateConfig(undefined extension reference) = Valid true, want false")
+	}
+
+	found := false
+	for _, e := range result.Errors {
+		if e.Path == "service.extensions" {
+			found = true
+		}
+	}
+	if !found {
+		t.Errorf("validateConfig didn't report the undefined extension reference, got %+v", result.Errors)
+	}
+}
@@ -0,0 +1,71 @@
+package main
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// GroupMembership reports a defined group's selector alongside the instance
+// IDs it currently resolves to.
+type GroupMembership struct {
+	Name        string   `json:"name"`
+	Selector    string   `json:"selector"`
+	InstanceIDs []string `json:"instance_ids"`
+}
+
+// handleGroups serves GET and POST /groups: GET lists every defined group
+// with its current membership, POST defines or redefines a group's selector.
+func (s *OpAMPServer) handleGroups(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+		s.handleListGroups(w, r)
+	case http.MethodPost:
+		s.handleDefineGroup(w, r)
+	default:
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+	}
+}
+
+func (s *OpAMPServer) handleListGroups(w http.ResponseWriter, r *http.Request) {
+	groups := s.groupStore.All()
+
+	memberships := make([]GroupMembership, 0, len(groups))
+	for _, group := range groups {
+		ids, err := s.resolveSelector(group.Selector)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+		memberships = append(memberships, GroupMembership{
+			Name:        group.Name,
+			Selector:    group.Selector,
+			InstanceIDs: ids,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(memberships)
+}
+
+func (s *OpAMPServer) handleDefineGroup(w http.ResponseWriter, r *http.Request) {
+	var req Group
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Name == "" {
+		http.Error(w, "name is required", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := parseSelector(req.Selector); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	s.groupStore.Set(req.Name, req.Selector)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(req)
+}
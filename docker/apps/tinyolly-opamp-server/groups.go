@@ -0,0 +1,108 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Group is a named label selector operators can target with a single config
+// push instead of enumerating instance IDs by hand.
+type Group struct {
+	Name     string `json:"name"`
+	Selector string `json:"selector"`
+}
+
+// GroupStore keeps the named groups operators have defined, keyed by name.
+type GroupStore struct {
+	mu     sync.RWMutex
+	groups map[string]string // name -> selector
+}
+
+// NewGroupStore creates an empty group store.
+func NewGroupStore() *GroupStore {
+	return &GroupStore{groups: make(map[string]string)}
+}
+
+// Set defines or redefines a named group's selector.
+func (g *GroupStore) Set(name, selector string) {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.groups[name] = selector
+}
+
+// Selector returns the selector registered for name, if any.
+func (g *GroupStore) Selector(name string) (string, bool) {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	selector, ok := g.groups[name]
+	return selector, ok
+}
+
+// All returns every defined group, in no particular order.
+func (g *GroupStore) All() []Group {
+	g.mu.RLock()
+	defer g.mu.RUnlock()
+	out := make([]Group, 0, len(g.groups))
+	for name, selector := range g.groups {
+		out = append(out, Group{Name: name, Selector: selector})
+	}
+	return out
+}
+
+// parseSelector parses a comma-separated list of key=value terms, e.g.
+// "env=prod,region=eu-west", into the label values an agent must match. All
+// terms must match for an agent to be selected (AND semantics). An empty
+// selector matches every agent.
+func parseSelector(selector string) (map[string]string, error) {
+	requirements := make(map[string]string)
+	if strings.TrimSpace(selector) == "" {
+		return requirements, nil
+	}
+
+	for _, term := range strings.Split(selector, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+		key, value, ok := strings.Cut(term, "=")
+		key = strings.TrimSpace(key)
+		if !ok || key == "" {
+			return nil, fmt.Errorf("invalid selector term %q: expected key=value", term)
+		}
+		requirements[key] = strings.TrimSpace(value)
+	}
+
+	return requirements, nil
+}
+
+// matchesSelector reports whether labels satisfies every requirement parsed
+// from a selector.
+func matchesSelector(labels map[string]string, requirements map[string]string) bool {
+	for key, value := range requirements {
+		if labels[key] != value {
+			return false
+		}
+	}
+	return true
+}
+
+// resolveSelector returns the instance IDs of currently known agents whose
+// labels satisfy selector.
+func (s *OpAMPServer) resolveSelector(selector string) ([]string, error) {
+	requirements, err := parseSelector(selector)
+	if err != nil {
+		return nil, err
+	}
+
+	s.agentsMu.RLock()
+	defer s.agentsMu.RUnlock()
+
+	var ids []string
+	for id, agent := range s.agents {
+		if matchesSelector(agent.Labels, requirements) {
+			ids = append(ids, id)
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		name     string
+		selector string
+		want     map[string]string
+		wantErr  bool
+	}{
+		{name: "empty selector matches everything", selector: "", want: map[string]string{}},
+		{name: "blank selector matches everything", selector: "   ", want: map[string]string{}},
+		{name: "single term", selector: "env=prod", want: map[string]string{"env": "prod"}},
+		{
+			name:     "multiple terms",
+			selector: "env=prod,region=eu-west",
+			want:     map[string]string{"env": "prod", "region": "eu-west"},
+		},
+		{
+			name:     "whitespace around terms and values is trimmed",
+			selector: " env = prod , region=eu-west ",
+			want:     map[string]string{"env": "prod", "region": "eu-west"},
+		},
+		{name: "empty term between commas is ignored", selector: "env=prod,,region=eu-west", want: map[string]string{"env": "prod", "region": "eu-west"}},
+		{name: "value may be empty", selector: "env=", want: map[string]string{"env": ""}},
+		{name: "missing equals is an error", selector: "env", wantErr: true},
+		{name: "empty key is an error", selector: "=prod", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseSelector(tt.selector)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseSelector(%q) = %v, nil; want error", tt.selector, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseSelector(%q) returned unexpected error: %v", tt.selector, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSelector(%q) = %v, want %v", tt.selector, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMatchesSelector(t *testing.T) {
+	tests := []struct {
+		name         string
+		labels       map[string]string
+		requirements map[string]string
+		want         bool
+	}{
+		{
+			name:         "empty requirements always match",
+			labels:       map[string]string{"env": "prod"},
+			requirements: map[string]string{},
+			want:         true,
+		},
+		{
+			name:         "all requirements satisfied",
+			labels:       map[string]string{"env": "prod", "region": "eu-west"},
+			requirements: map[string]string{"env": "prod", "region": "eu-west"},
+			want:         true,
+		},
+		{
+			name:         "one requirement unsatisfied",
+			labels:       map[string]string{"env": "staging", "region": "eu-west"},
+			requirements: map[string]string{"env": "prod", "region": "eu-west"},
+			want:         false,
+		},
+		{
+			name:         "requirement key missing from labels",
+			labels:       map[string]string{"region": "eu-west"},
+			requirements: map[string]string{"env": "prod"},
+			want:         false,
+		},
+		{
+			name:         "nil labels never match a non-empty requirement",
+			labels:       nil,
+			requirements: map[string]string{"env": "prod"},
+			want:         false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchesSelector(tt.labels, tt.requirements); got != tt.want {
+				t.Errorf("matchesSelector(%v, %v) = %v, want %v", tt.labels, tt.requirements, got, tt.want)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ package main
 
 import (
 	"context"
+	"crypto/sha256"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
@@ -13,6 +14,7 @@ import (
 	"net/http"
 	"os"
 	"path/filepath"
+	"strings"
 	"sync"
 	"time"
 
@@ -23,37 +25,112 @@ import (
 
 // AgentState tracks the state of a connected OTel Collector agent
 type AgentState struct {
-	InstanceID      string           `json:"instance_id"`
-	AgentType       string           `json:"agent_type"`
-	AgentVersion    string           `json:"agent_version"`
-	EffectiveConfig string           `json:"effective_config"`
-	LastSeen        time.Time        `json:"last_seen"`
-	Status          string           `json:"status"`
-	conn            types.Connection `json:"-"`
+	InstanceID       string            `json:"instance_id"`
+	AgentType        string            `json:"agent_type"`
+	AgentVersion     string            `json:"agent_version"`
+	EffectiveConfig  string            `json:"effective_config"`
+	LastSeen         time.Time         `json:"last_seen"`
+	Status           string            `json:"status"`
+	LastConfigHash   string            `json:"last_config_hash,omitempty"`
+	LastConfigStatus string            `json:"last_config_status,omitempty"`
+	LastConfigError  string            `json:"last_config_error,omitempty"`
+	LastGoodHash     string            `json:"last_good_hash,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
+	Capabilities     uint64            `json:"capabilities"`
+	lastSentConfig   string            `json:"-"`
+	lastGoodConfig   string            `json:"-"`
+	conn             types.Connection  `json:"-"`
 }
 
 // OpAMPServer wraps the OpAMP server with REST API
 type OpAMPServer struct {
-	opampServer    server.OpAMPServer
-	agents         map[string]*AgentState      // keyed by instance ID
-	connToAgent    map[types.Connection]string // maps connection to instance ID
-	agentsMu       sync.RWMutex
-	pendingConfigs map[string]string // instanceID -> pending config
-	configMu       sync.RWMutex
-	currentConfig  string
+	wsServer         server.OpAMPServer          // serves the OpAMP WebSocket transport
+	httpServer       server.OpAMPServer          // serves the OpAMP plain-HTTP transport
+	opampConnContext server.ConnContext          // set by attachOpAMPHTTP; must be wired onto the REST http.Server
+	agents           map[string]*AgentState      // keyed by instance ID
+	connToAgent      map[types.Connection]string // maps connection to instance ID
+	agentsMu         sync.RWMutex
+	pendingConfigs   map[string]string // instanceID -> pending config
+	configMu         sync.RWMutex
+	currentConfig    string
+	currentConfigMu  sync.RWMutex // guards currentConfig, written by handleUpdateConfig and handleConfigRollback
+	configStore      *ConfigStore
+	groupStore       *GroupStore
+	store            Store
+
+	// deliveryMu guards connection settings and commands queued for delivery
+	// the next time the named agent checks in, mirroring how pendingConfigs
+	// is drained in onMessage.
+	deliveryMu                sync.Mutex
+	pendingConnectionSettings map[string]*protobufs.ConnectionSettingsOffers
+	pendingCommands           map[string]protobufs.CommandType
 }
 
 // NewOpAMPServer creates a new OpAMP server instance
 func NewOpAMPServer() *OpAMPServer {
 	s := &OpAMPServer{
-		agents:         make(map[string]*AgentState),
-		connToAgent:    make(map[types.Connection]string),
-		pendingConfigs: make(map[string]string),
+		agents:                    make(map[string]*AgentState),
+		connToAgent:               make(map[types.Connection]string),
+		pendingConfigs:            make(map[string]string),
+		configStore:               NewConfigStore(),
+		groupStore:                NewGroupStore(),
+		pendingConnectionSettings: make(map[string]*protobufs.ConnectionSettingsOffers),
+		pendingCommands:           make(map[string]protobufs.CommandType),
 	}
 	s.loadInitialConfig()
+	s.configStore.Add(s.currentConfig, "system", "initial config")
+	s.openStore()
+	s.restoreState()
 	return s
 }
 
+// openStore opens the persistence backend configured via OPAMP_STORE_PATH.
+// If it can't be opened, the server falls back to an in-memory noopStore
+// rather than refusing to start.
+func (s *OpAMPServer) openStore() {
+	storePath := os.Getenv("OPAMP_STORE_PATH")
+	if storePath == "" {
+		storePath = "./opamp-state.db"
+	}
+
+	store, err := newBoltStore(storePath)
+	if err != nil {
+		log.Printf("Persistence disabled, continuing in memory only: %v", err)
+		s.store = noopStore{}
+		return
+	}
+
+	log.Printf("Persisting agent state to %s", storePath)
+	s.store = store
+}
+
+// restoreState loads previously persisted agents and pending configs so a
+// server restart doesn't lose track of known agents or queued config
+// deliveries.
+func (s *OpAMPServer) restoreState() {
+	agents, err := s.store.LoadAgents()
+	if err != nil {
+		log.Printf("Failed to restore agent state: %v", err)
+	}
+	for instanceID, agent := range agents {
+		s.agents[instanceID] = agent
+	}
+	if len(agents) > 0 {
+		log.Printf("Restored %d agent(s) from persistent store", len(agents))
+	}
+
+	pending, err := s.store.LoadPendingConfigs()
+	if err != nil {
+		log.Printf("Failed to restore pending configs: %v", err)
+	}
+	for instanceID, config := range pending {
+		s.pendingConfigs[instanceID] = config
+	}
+	if len(pending) > 0 {
+		log.Printf("Restored %d pending config(s) from persistent store", len(pending))
+	}
+}
+
 // loadInitialConfig attempts to load the default collector config from file
 func (s *OpAMPServer) loadInitialConfig() {
 	configPaths := []string{
@@ -80,6 +157,22 @@ func (s *OpAMPServer) loadInitialConfig() {
 	log.Printf("Using default config (no config file found)")
 }
 
+// setCurrentConfig updates the server's in-memory "current" config, guarding
+// against the concurrent writes handleUpdateConfig and handleConfigRollback
+// can both make.
+func (s *OpAMPServer) setCurrentConfig(config string) {
+	s.currentConfigMu.Lock()
+	s.currentConfig = config
+	s.currentConfigMu.Unlock()
+}
+
+// getCurrentConfig returns the server's in-memory "current" config.
+func (s *OpAMPServer) getCurrentConfig() string {
+	s.currentConfigMu.RLock()
+	defer s.currentConfigMu.RUnlock()
+	return s.currentConfig
+}
+
 // OnConnecting handles new connections
 func (s *OpAMPServer) OnConnecting(request *http.Request) types.ConnectionResponse {
 	log.Printf("Agent connecting from %s", request.RemoteAddr)
@@ -98,6 +191,21 @@ func (s *OpAMPServer) onConnected(ctx context.Context, conn types.Connection) {
 	log.Printf("Agent connected")
 }
 
+// remoteConfigStatusString renders an OpAMP RemoteConfigStatuses value as the
+// string stored on AgentState and returned over the REST API.
+func remoteConfigStatusString(status protobufs.RemoteConfigStatuses) string {
+	switch status {
+	case protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLYING:
+		return "APPLYING"
+	case protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED:
+		return "APPLIED"
+	case protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED:
+		return "FAILED"
+	default:
+		return "UNSET"
+	}
+}
+
 // onMessage handles messages from agents
 func (s *OpAMPServer) onMessage(ctx context.Context, conn types.Connection, msg *protobufs.AgentToServer) *protobufs.ServerToAgent {
 	// Extract instance ID from the message
@@ -123,17 +231,25 @@ func (s *OpAMPServer) onMessage(ctx context.Context, conn types.Connection, msg
 			conn:       conn,
 		}
 		s.agents[instanceID] = agent
-		s.connToAgent[conn] = instanceID
 		log.Printf("New agent registered: %s", instanceID)
 	}
+	// Always (re)register the connection, not just on first registration: a
+	// restored agent already exists in s.agents after a restart, but its
+	// connToAgent entry doesn't survive restarts, and each reconnect gets a
+	// new types.Connection regardless of whether the agent existed before.
+	s.connToAgent[conn] = instanceID
 
 	agent.LastSeen = time.Now()
 	agent.Status = "connected"
 	agent.conn = conn
+	agent.Capabilities = msg.Capabilities
 
-	// Extract agent description
+	// Extract agent description, including the labels used for group/selector
+	// config targeting
 	if msg.AgentDescription != nil {
+		labels := make(map[string]string)
 		for _, attr := range msg.AgentDescription.IdentifyingAttributes {
+			labels[attr.Key] = attr.Value.GetStringValue()
 			if attr.Key == "service.name" {
 				agent.AgentType = attr.Value.GetStringValue()
 			}
@@ -141,6 +257,10 @@ func (s *OpAMPServer) onMessage(ctx context.Context, conn types.Connection, msg
 				agent.AgentVersion = attr.Value.GetStringValue()
 			}
 		}
+		for _, attr := range msg.AgentDescription.NonIdentifyingAttributes {
+			labels[attr.Key] = attr.Value.GetStringValue()
+		}
+		agent.Labels = labels
 	}
 
 	// Extract effective config
@@ -151,6 +271,23 @@ func (s *OpAMPServer) onMessage(ctx context.Context, conn types.Connection, msg
 		}
 	}
 
+	// Record the outcome of the last config the agent tried to apply
+	if rcs := msg.RemoteConfigStatus; rcs != nil {
+		agent.LastConfigHash = hex.EncodeToString(rcs.LastRemoteConfigHash)
+		agent.LastConfigStatus = remoteConfigStatusString(rcs.Status)
+		agent.LastConfigError = rcs.ErrorMessage
+		if rcs.Status == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_APPLIED {
+			agent.LastGoodHash = agent.LastConfigHash
+			agent.lastGoodConfig = agent.lastSentConfig
+		} else if rcs.Status == protobufs.RemoteConfigStatuses_RemoteConfigStatuses_FAILED {
+			log.Printf("Agent %s failed to apply config %s: %s", instanceID, agent.LastConfigHash, rcs.ErrorMessage)
+		}
+	}
+
+	if err := s.store.SaveAgent(agent); err != nil {
+		log.Printf("Failed to persist agent %s: %v", instanceID, err)
+	}
+
 	s.agentsMu.Unlock()
 
 	// Check if there's a pending config for this agent
@@ -158,6 +295,9 @@ func (s *OpAMPServer) onMessage(ctx context.Context, conn types.Connection, msg
 	pendingConfig, hasPending := s.pendingConfigs[instanceID]
 	if hasPending {
 		delete(s.pendingConfigs, instanceID)
+		if err := s.store.DeletePendingConfig(instanceID); err != nil {
+			log.Printf("Failed to remove persisted pending config for %s: %v", instanceID, err)
+		}
 	}
 	s.configMu.Unlock()
 
@@ -165,15 +305,35 @@ func (s *OpAMPServer) onMessage(ctx context.Context, conn types.Connection, msg
 
 	if hasPending {
 		log.Printf("Sending pending config to agent %s", instanceID)
+		hash := sha256.Sum256([]byte(pendingConfig))
 		response.RemoteConfig = &protobufs.AgentRemoteConfig{
 			Config: &protobufs.AgentConfigMap{
 				ConfigMap: map[string]*protobufs.AgentConfigFile{
 					"": {Body: []byte(pendingConfig)},
 				},
 			},
-			ConfigHash: []byte(fmt.Sprintf("%d", time.Now().UnixNano())),
+			ConfigHash: hash[:],
 		}
+
+		s.agentsMu.Lock()
+		agent.lastSentConfig = pendingConfig
+		s.agentsMu.Unlock()
+	}
+
+	// Check if there's a connection settings offer or command queued for
+	// this agent and deliver at most one of each per check-in.
+	s.deliveryMu.Lock()
+	if offers, ok := s.pendingConnectionSettings[instanceID]; ok {
+		log.Printf("Sending connection settings offer to agent %s", instanceID)
+		response.ConnectionSettings = offers
+		delete(s.pendingConnectionSettings, instanceID)
+	}
+	if commandType, ok := s.pendingCommands[instanceID]; ok {
+		log.Printf("Sending %s command to agent %s", commandType, instanceID)
+		response.Command = &protobufs.ServerToAgentCommand{Type: commandType}
+		delete(s.pendingCommands, instanceID)
 	}
+	s.deliveryMu.Unlock()
 
 	return response
 }
@@ -188,6 +348,9 @@ func (s *OpAMPServer) onConnectionClose(conn types.Connection) {
 			agent.Status = "disconnected"
 			agent.LastSeen = time.Now()
 			log.Printf("Agent disconnected: %s", instanceID)
+			if err := s.store.SaveAgent(agent); err != nil {
+				log.Printf("Failed to persist agent %s: %v", instanceID, err)
+			}
 		}
 		delete(s.connToAgent, conn)
 	}
@@ -202,13 +365,22 @@ type StatusResponse struct {
 }
 
 type ConfigUpdateRequest struct {
-	Config     string `json:"config"`
-	InstanceID string `json:"instance_id,omitempty"`
+	Config      string `json:"config"`
+	InstanceID  string `json:"instance_id,omitempty"`
+	Group       string `json:"group,omitempty"`
+	Selector    string `json:"selector,omitempty"`
+	Author      string `json:"author,omitempty"`
+	Description string `json:"description,omitempty"`
+	// Force skips config validation, for configs validateConfig can't yet
+	// judge correctly (e.g. custom components it doesn't know about).
+	Force bool `json:"force,omitempty"`
 }
 
 type ConfigUpdateResponse struct {
 	Status      string   `json:"status"`
 	Message     string   `json:"message"`
+	Hash        string   `json:"hash"`
+	Version     int      `json:"version"`
 	AffectedIDs []string `json:"affected_instance_ids"`
 }
 
@@ -237,6 +409,66 @@ func (s *OpAMPServer) handleStatus(w http.ResponseWriter, r *http.Request) {
 	json.NewEncoder(w).Encode(response)
 }
 
+// AgentConfigStatusResponse reports what an agent last told the server about
+// the config it tried to apply, so the UI can surface FAILED deliveries
+// without scraping the full /status payload.
+type AgentConfigStatusResponse struct {
+	InstanceID string `json:"instance_id"`
+	Status     string `json:"status"`
+	Hash       string `json:"hash,omitempty"`
+	Error      string `json:"error,omitempty"`
+	GoodHash   string `json:"last_good_hash,omitempty"`
+}
+
+// handleAgentRoutes dispatches /agents/{id}/{action} requests to the
+// per-agent handler for action.
+func (s *OpAMPServer) handleAgentRoutes(w http.ResponseWriter, r *http.Request) {
+	path := strings.TrimPrefix(r.URL.Path, "/agents/")
+	instanceID, action, ok := strings.Cut(path, "/")
+	if !ok || instanceID == "" || action == "" {
+		http.Error(w, "Expected /agents/{id}/{action}", http.StatusBadRequest)
+		return
+	}
+
+	switch action {
+	case "config-status":
+		s.handleAgentConfigStatus(w, r, instanceID)
+	case "telemetry-endpoint":
+		s.handleTelemetryEndpoint(w, r, instanceID)
+	case "restart":
+		s.handleRestartAgent(w, r, instanceID)
+	default:
+		http.Error(w, "Unknown agent action", http.StatusNotFound)
+	}
+}
+
+// handleAgentConfigStatus serves GET /agents/{id}/config-status, the
+// structured counterpart to polling /status for a single agent's
+// RemoteConfigStatus.
+func (s *OpAMPServer) handleAgentConfigStatus(w http.ResponseWriter, r *http.Request, instanceID string) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.agentsMu.RLock()
+	agent, exists := s.agents[instanceID]
+	s.agentsMu.RUnlock()
+	if !exists {
+		http.Error(w, "Agent not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(AgentConfigStatusResponse{
+		InstanceID: instanceID,
+		Status:     agent.LastConfigStatus,
+		Hash:       agent.LastConfigHash,
+		Error:      agent.LastConfigError,
+		GoodHash:   agent.LastGoodHash,
+	})
+}
+
 func (s *OpAMPServer) handleGetConfig(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodGet {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -277,11 +509,50 @@ func (s *OpAMPServer) handleGetConfig(w http.ResponseWriter, r *http.Request) {
 
 	w.Header().Set("Content-Type", "application/json")
 	json.NewEncoder(w).Encode(map[string]interface{}{
-		"config": s.currentConfig,
+		"config": s.getCurrentConfig(),
 		"status": "no_agents_connected",
 	})
 }
 
+// queueConfigForIDs marks config as the pending config for each of ids,
+// persisting the queue so it survives a restart.
+func (s *OpAMPServer) queueConfigForIDs(config string, ids []string) {
+	s.configMu.Lock()
+	defer s.configMu.Unlock()
+
+	for _, id := range ids {
+		s.pendingConfigs[id] = config
+		if err := s.store.SavePendingConfig(id, config); err != nil {
+			log.Printf("Failed to persist pending config for %s: %v", id, err)
+		}
+		log.Printf("Queued config update for agent %s", id)
+	}
+}
+
+// queueConfigForAgents queues config as the pending config for instanceID,
+// or for every connected agent if instanceID is empty, persisting the queue
+// so it survives a restart. It returns the instance IDs that were queued.
+func (s *OpAMPServer) queueConfigForAgents(config, instanceID string) []string {
+	s.agentsMu.RLock()
+	var affectedIDs []string
+
+	if instanceID != "" {
+		if _, exists := s.agents[instanceID]; exists {
+			affectedIDs = append(affectedIDs, instanceID)
+		}
+	} else {
+		for id, agent := range s.agents {
+			if agent.Status == "connected" {
+				affectedIDs = append(affectedIDs, id)
+			}
+		}
+	}
+	s.agentsMu.RUnlock()
+
+	s.queueConfigForIDs(config, affectedIDs)
+	return affectedIDs
+}
+
 func (s *OpAMPServer) handleUpdateConfig(w http.ResponseWriter, r *http.Request) {
 	if r.Method != http.MethodPost && r.Method != http.MethodPut {
 		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
@@ -299,34 +570,52 @@ func (s *OpAMPServer) handleUpdateConfig(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	s.currentConfig = req.Config
+	if result := validateConfig(req.Config); !result.Valid && !req.Force {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		json.NewEncoder(w).Encode(result)
+		return
+	}
 
-	s.agentsMu.RLock()
+	// Validate the group/selector before recording a new config version, so a
+	// 404/400 rejection doesn't leave a phantom entry in configStore.history.
+	var version *ConfigVersion
 	var affectedIDs []string
-
-	if req.InstanceID != "" {
-		if _, exists := s.agents[req.InstanceID]; exists {
-			affectedIDs = append(affectedIDs, req.InstanceID)
+	switch {
+	case req.Group != "":
+		selector, ok := s.groupStore.Selector(req.Group)
+		if !ok {
+			http.Error(w, fmt.Sprintf("Group %q not found", req.Group), http.StatusNotFound)
+			return
 		}
-	} else {
-		for id, agent := range s.agents {
-			if agent.Status == "connected" {
-				affectedIDs = append(affectedIDs, id)
-			}
+		ids, err := s.resolveSelector(selector)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
 		}
+		version = s.configStore.Add(req.Config, req.Author, req.Description)
+		s.queueConfigForIDs(req.Config, ids)
+		affectedIDs = ids
+	case req.Selector != "":
+		ids, err := s.resolveSelector(req.Selector)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		version = s.configStore.Add(req.Config, req.Author, req.Description)
+		s.queueConfigForIDs(req.Config, ids)
+		affectedIDs = ids
+	default:
+		version = s.configStore.Add(req.Config, req.Author, req.Description)
+		s.setCurrentConfig(req.Config)
+		affectedIDs = s.queueConfigForAgents(req.Config, req.InstanceID)
 	}
-	s.agentsMu.RUnlock()
-
-	s.configMu.Lock()
-	for _, id := range affectedIDs {
-		s.pendingConfigs[id] = req.Config
-		log.Printf("Queued config update for agent %s", id)
-	}
-	s.configMu.Unlock()
 
 	response := ConfigUpdateResponse{
 		Status:      "pending",
 		Message:     fmt.Sprintf("Config update queued for %d agent(s)", len(affectedIDs)),
+		Hash:        version.Hash,
+		Version:     version.Version,
 		AffectedIDs: affectedIDs,
 	}
 
@@ -418,26 +707,9 @@ func main() {
 	}
 
 	s := NewOpAMPServer()
+	defer s.store.Close()
 
-	// Create OpAMP server
-	s.opampServer = server.New(nil) // nil logger uses default
-
-	// Start OpAMP server in goroutine
-	go func() {
-		settings := server.StartSettings{
-			Settings: server.Settings{
-				Callbacks: types.Callbacks{
-					OnConnecting: s.OnConnecting,
-				},
-			},
-			ListenEndpoint: fmt.Sprintf("0.0.0.0:%s", opampPort),
-		}
-
-		log.Printf("Starting OpAMP WebSocket server on port %s", opampPort)
-		if err := s.opampServer.Start(settings); err != nil {
-			log.Fatalf("Failed to start OpAMP server: %v", err)
-		}
-	}()
+	opampTransport := os.Getenv("OPAMP_TRANSPORT")
 
 	// Setup HTTP REST API
 	mux := http.NewServeMux()
@@ -455,10 +727,22 @@ func main() {
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		}
 	})
+	mux.HandleFunc("/config/history", s.handleConfigHistory)
+	mux.HandleFunc("/config/rollback", s.handleConfigRollback)
+	mux.HandleFunc("/config/retry", s.handleConfigRetry)
+	mux.HandleFunc("/config/validate", s.handleValidateConfig)
+	mux.HandleFunc("/config/", s.handleConfigByHash)
+	mux.HandleFunc("/agents/", s.handleAgentRoutes)
+	mux.HandleFunc("/groups", s.handleGroups)
+
+	// Start the configured OpAMP transport(s). The http transport attaches
+	// to this same mux; the ws transport gets its own listener on opampPort.
+	s.setupOpAMPTransports(opampTransport, opampPort, mux)
 
 	httpServer := &http.Server{
-		Addr:    fmt.Sprintf(":%s", httpPort),
-		Handler: corsMiddleware(mux),
+		Addr:        fmt.Sprintf(":%s", httpPort),
+		Handler:     corsMiddleware(mux),
+		ConnContext: s.opampConnContext,
 	}
 
 	log.Printf("Starting HTTP REST API on port %s", httpPort)
@@ -467,6 +751,16 @@ func main() {
 	log.Printf("  GET  /status - Get connected agents status")
 	log.Printf("  GET  /config - Get current collector config")
 	log.Printf("  POST /config - Update collector config")
+	log.Printf("  GET  /config/history - List recorded config versions")
+	log.Printf("  GET  /config/{hash} - Get a specific config version")
+	log.Printf("  POST /config/rollback - Queue a previous config version")
+	log.Printf("  POST /config/retry - Retry or revert a failed config delivery")
+	log.Printf("  POST /config/validate - Validate a config without queueing it")
+	log.Printf("  GET  /agents/{id}/config-status - Get an agent's last RemoteConfigStatus")
+	log.Printf("  POST /agents/{id}/telemetry-endpoint - Push own-telemetry connection settings to an agent")
+	log.Printf("  POST /agents/{id}/restart - Queue a restart command for an agent")
+	log.Printf("  GET  /groups - List defined groups and their membership")
+	log.Printf("  POST /groups - Define a named group's label selector")
 
 	if err := httpServer.ListenAndServe(); err != nil {
 		log.Fatalf("Failed to start HTTP server: %v", err)
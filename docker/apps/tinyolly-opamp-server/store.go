@@ -0,0 +1,182 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	bolt "go.etcd.io/bbolt"
+)
+
+// persistedAgent is the on-disk representation of an AgentState. It mirrors
+// AgentState but drops the live connection, which cannot (and should not)
+// survive a restart.
+type persistedAgent struct {
+	InstanceID       string            `json:"instance_id"`
+	AgentType        string            `json:"agent_type"`
+	AgentVersion     string            `json:"agent_version"`
+	EffectiveConfig  string            `json:"effective_config"`
+	LastSeen         time.Time         `json:"last_seen"`
+	Status           string            `json:"status"`
+	LastConfigHash   string            `json:"last_config_hash,omitempty"`
+	LastConfigStatus string            `json:"last_config_status,omitempty"`
+	LastConfigError  string            `json:"last_config_error,omitempty"`
+	LastGoodHash     string            `json:"last_good_hash,omitempty"`
+	LastSentConfig   string            `json:"last_sent_config,omitempty"`
+	LastGoodConfig   string            `json:"last_good_config,omitempty"`
+	Labels           map[string]string `json:"labels,omitempty"`
+	Capabilities     uint64            `json:"capabilities,omitempty"`
+}
+
+// Store persists agent state and pending configs so the server can resume
+// after a restart without losing track of agents it has already seen.
+type Store interface {
+	// LoadAgents returns every previously persisted agent, keyed by instance ID.
+	LoadAgents() (map[string]*AgentState, error)
+	// SaveAgent upserts the given agent's persisted fields.
+	SaveAgent(agent *AgentState) error
+	// LoadPendingConfigs returns the pendingConfigs map as it was last saved.
+	LoadPendingConfigs() (map[string]string, error)
+	// SavePendingConfig records (or updates) the pending config for an instance.
+	SavePendingConfig(instanceID, config string) error
+	// DeletePendingConfig removes a pending config, e.g. once delivered.
+	DeletePendingConfig(instanceID string) error
+	// Close releases any resources held by the store.
+	Close() error
+}
+
+var (
+	agentsBucket         = []byte("agents")
+	pendingConfigsBucket = []byte("pending_configs")
+)
+
+// boltStore is a Store backed by a single BoltDB file. It requires no
+// external services, which keeps the server's deployment footprint small.
+type boltStore struct {
+	db *bolt.DB
+}
+
+// newBoltStore opens (creating if necessary) a BoltDB file at path and
+// ensures the buckets used by the server exist.
+func newBoltStore(path string) (*boltStore, error) {
+	db, err := bolt.Open(path, 0600, &bolt.Options{Timeout: 1 * time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("opening bolt store at %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(agentsBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(pendingConfigsBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("initializing bolt store buckets: %w", err)
+	}
+
+	return &boltStore{db: db}, nil
+}
+
+func (b *boltStore) LoadAgents() (map[string]*AgentState, error) {
+	agents := make(map[string]*AgentState)
+
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(agentsBucket)
+		return bucket.ForEach(func(k, v []byte) error {
+			var p persistedAgent
+			if err := json.Unmarshal(v, &p); err != nil {
+				return fmt.Errorf("decoding persisted agent %s: %w", k, err)
+			}
+			agents[p.InstanceID] = &AgentState{
+				InstanceID:       p.InstanceID,
+				AgentType:        p.AgentType,
+				AgentVersion:     p.AgentVersion,
+				EffectiveConfig:  p.EffectiveConfig,
+				LastSeen:         p.LastSeen,
+				Status:           "disconnected",
+				LastConfigHash:   p.LastConfigHash,
+				LastConfigStatus: p.LastConfigStatus,
+				LastConfigError:  p.LastConfigError,
+				LastGoodHash:     p.LastGoodHash,
+				lastSentConfig:   p.LastSentConfig,
+				lastGoodConfig:   p.LastGoodConfig,
+				Labels:           p.Labels,
+				Capabilities:     p.Capabilities,
+			}
+			return nil
+		})
+	})
+
+	return agents, err
+}
+
+func (b *boltStore) SaveAgent(agent *AgentState) error {
+	p := persistedAgent{
+		InstanceID:       agent.InstanceID,
+		AgentType:        agent.AgentType,
+		AgentVersion:     agent.AgentVersion,
+		EffectiveConfig:  agent.EffectiveConfig,
+		LastSeen:         agent.LastSeen,
+		Status:           agent.Status,
+		LastConfigHash:   agent.LastConfigHash,
+		LastConfigStatus: agent.LastConfigStatus,
+		LastConfigError:  agent.LastConfigError,
+		LastGoodHash:     agent.LastGoodHash,
+		LastSentConfig:   agent.lastSentConfig,
+		LastGoodConfig:   agent.lastGoodConfig,
+		Labels:           agent.Labels,
+		Capabilities:     agent.Capabilities,
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("encoding agent %s: %w", agent.InstanceID, err)
+	}
+
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(agentsBucket).Put([]byte(agent.InstanceID), data)
+	})
+}
+
+func (b *boltStore) LoadPendingConfigs() (map[string]string, error) {
+	pending := make(map[string]string)
+
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bucket := tx.Bucket(pendingConfigsBucket)
+		return bucket.ForEach(func(k, v []byte) error {
+			pending[string(k)] = string(v)
+			return nil
+		})
+	})
+
+	return pending, err
+}
+
+func (b *boltStore) SavePendingConfig(instanceID, config string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(pendingConfigsBucket).Put([]byte(instanceID), []byte(config))
+	})
+}
+
+func (b *boltStore) DeletePendingConfig(instanceID string) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(pendingConfigsBucket).Delete([]byte(instanceID))
+	})
+}
+
+func (b *boltStore) Close() error {
+	return b.db.Close()
+}
+
+// noopStore is used when persistence can't be initialized, so the server can
+// still run in memory-only mode rather than failing to start.
+type noopStore struct{}
+
+func (noopStore) LoadAgents() (map[string]*AgentState, error)       { return nil, nil }
+func (noopStore) SaveAgent(*AgentState) error                       { return nil }
+func (noopStore) LoadPendingConfigs() (map[string]string, error)    { return nil, nil }
+func (noopStore) SavePendingConfig(instanceID, config string) error { return nil }
+func (noopStore) DeletePendingConfig(instanceID string) error       { return nil }
+func (noopStore) Close() error                                      { return nil }
@@ -0,0 +1,178 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/open-telemetry/opamp-go/protobufs"
+)
+
+// TLSMaterial carries optional client certificate material to push to an
+// agent alongside its own-telemetry connection settings.
+type TLSMaterial struct {
+	Cert   string `json:"cert,omitempty"`
+	Key    string `json:"key,omitempty"`
+	CACert string `json:"ca_cert,omitempty"`
+}
+
+// TelemetryEndpointRequest asks the server to offer an agent new OTLP
+// connection settings for its own metrics, traces, and/or logs.
+type TelemetryEndpointRequest struct {
+	Endpoint string            `json:"endpoint"`
+	Headers  map[string]string `json:"headers,omitempty"`
+	// Signals selects which of the agent's own-telemetry streams to target;
+	// valid entries are "metrics", "traces", and "logs". Defaults to all three.
+	Signals []string     `json:"signals,omitempty"`
+	TLS     *TLSMaterial `json:"tls,omitempty"`
+}
+
+var defaultTelemetrySignals = []string{"metrics", "traces", "logs"}
+
+// signalCapabilities maps each own-telemetry signal to the AgentCapabilities
+// bit an agent must advertise before the server will push settings for it.
+var signalCapabilities = map[string]protobufs.AgentCapabilities{
+	"metrics": protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnMetrics,
+	"traces":  protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnTraces,
+	"logs":    protobufs.AgentCapabilities_AgentCapabilities_ReportsOwnLogs,
+}
+
+// handleTelemetryEndpoint serves POST /agents/{id}/telemetry-endpoint,
+// queueing an OpAMP ConnectionSettingsOffers for the agent's own metrics,
+// traces, and/or logs destinations, to be delivered on its next check-in.
+func (s *OpAMPServer) handleTelemetryEndpoint(w http.ResponseWriter, r *http.Request, instanceID string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	var req TelemetryEndpointRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "Invalid JSON: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if req.Endpoint == "" {
+		http.Error(w, "endpoint is required", http.StatusBadRequest)
+		return
+	}
+
+	signals := req.Signals
+	if len(signals) == 0 {
+		signals = defaultTelemetrySignals
+	}
+
+	s.agentsMu.RLock()
+	agent, exists := s.agents[instanceID]
+	var capabilities uint64
+	if exists {
+		capabilities = agent.Capabilities
+	}
+	s.agentsMu.RUnlock()
+	if !exists {
+		http.Error(w, "Agent not found", http.StatusNotFound)
+		return
+	}
+
+	settings := buildTelemetryConnectionSettings(req.Endpoint, req.Headers, req.TLS)
+	offers := &protobufs.ConnectionSettingsOffers{}
+
+	for _, signal := range signals {
+		capBit, known := signalCapabilities[signal]
+		if !known {
+			http.Error(w, fmt.Sprintf("Unknown signal %q: must be metrics, traces, or logs", signal), http.StatusBadRequest)
+			return
+		}
+		if !hasCapability(capabilities, capBit) {
+			http.Error(w, fmt.Sprintf("Agent does not advertise capability %s", capabilityNames[capBit]), http.StatusConflict)
+			return
+		}
+		switch signal {
+		case "metrics":
+			offers.OwnMetrics = settings
+		case "traces":
+			offers.OwnTraces = settings
+		case "logs":
+			offers.OwnLogs = settings
+		}
+	}
+
+	s.deliveryMu.Lock()
+	s.pendingConnectionSettings[instanceID] = offers
+	s.deliveryMu.Unlock()
+
+	log.Printf("Queued telemetry endpoint %s for agent %s (%v)", req.Endpoint, instanceID, signals)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":      "pending",
+		"instance_id": instanceID,
+		"endpoint":    req.Endpoint,
+		"signals":     signals,
+	})
+}
+
+// buildTelemetryConnectionSettings assembles a TelemetryConnectionSettings
+// message from the fields of a telemetry endpoint REST request.
+func buildTelemetryConnectionSettings(endpoint string, headers map[string]string, tls *TLSMaterial) *protobufs.TelemetryConnectionSettings {
+	settings := &protobufs.TelemetryConnectionSettings{DestinationEndpoint: endpoint}
+
+	if len(headers) > 0 {
+		hdrs := make([]*protobufs.Header, 0, len(headers))
+		for key, value := range headers {
+			hdrs = append(hdrs, &protobufs.Header{Key: key, Value: value})
+		}
+		settings.Headers = &protobufs.Headers{Headers: hdrs}
+	}
+
+	if tls != nil {
+		settings.Certificate = &protobufs.TLSCertificate{
+			Cert:       []byte(tls.Cert),
+			PrivateKey: []byte(tls.Key),
+			CaCert:     []byte(tls.CACert),
+		}
+	}
+
+	return settings
+}
+
+// handleRestartAgent serves POST /agents/{id}/restart, queueing an OpAMP
+// restart command to be delivered on the agent's next check-in.
+func (s *OpAMPServer) handleRestartAgent(w http.ResponseWriter, r *http.Request, instanceID string) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	s.agentsMu.RLock()
+	agent, exists := s.agents[instanceID]
+	var capabilities uint64
+	if exists {
+		capabilities = agent.Capabilities
+	}
+	s.agentsMu.RUnlock()
+	if !exists {
+		http.Error(w, "Agent not found", http.StatusNotFound)
+		return
+	}
+
+	restartCap := protobufs.AgentCapabilities_AgentCapabilities_AcceptsRestartCommand
+	if !hasCapability(capabilities, restartCap) {
+		http.Error(w, fmt.Sprintf("Agent does not advertise capability %s", capabilityNames[restartCap]), http.StatusConflict)
+		return
+	}
+
+	s.deliveryMu.Lock()
+	s.pendingCommands[instanceID] = protobufs.CommandType_CommandType_Restart
+	s.deliveryMu.Unlock()
+
+	log.Printf("Queued restart command for agent %s", instanceID)
+
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(map[string]interface{}{
+		"status":      "pending",
+		"instance_id": instanceID,
+		"command":     "restart",
+	})
+}
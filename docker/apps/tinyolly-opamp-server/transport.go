@@ -0,0 +1,74 @@
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/http"
+
+	"github.com/open-telemetry/opamp-go/server"
+	"github.com/open-telemetry/opamp-go/server/types"
+)
+
+// opampCallbacks returns the connection callbacks shared by every OpAMP
+// transport, so WebSocket and plain-HTTP agents are indistinguishable to the
+// REST API's view of connected agents.
+func (s *OpAMPServer) opampCallbacks() types.Callbacks {
+	return types.Callbacks{
+		OnConnecting: s.OnConnecting,
+	}
+}
+
+// setupOpAMPTransports starts the OpAMP transport(s) selected by
+// OPAMP_TRANSPORT (ws, http, or both; defaults to ws) and, for the http
+// transport, mounts the OpAMP endpoint onto the REST API mux so it shares
+// the same HTTP port as the rest of the server.
+func (s *OpAMPServer) setupOpAMPTransports(transport, opampPort string, mux *http.ServeMux) {
+	switch transport {
+	case "", "ws":
+		s.startOpAMPWebSocket(opampPort)
+	case "http":
+		s.attachOpAMPHTTP(mux)
+	case "both":
+		s.startOpAMPWebSocket(opampPort)
+		s.attachOpAMPHTTP(mux)
+	default:
+		log.Fatalf("Invalid OPAMP_TRANSPORT %q: must be ws, http, or both", transport)
+	}
+}
+
+// startOpAMPWebSocket starts the OpAMP WebSocket listener on its own port,
+// as the server has always done.
+func (s *OpAMPServer) startOpAMPWebSocket(opampPort string) {
+	s.wsServer = server.New(nil) // nil logger uses default
+
+	go func() {
+		settings := server.StartSettings{
+			Settings:       server.Settings{Callbacks: s.opampCallbacks()},
+			ListenEndpoint: fmt.Sprintf("0.0.0.0:%s", opampPort),
+		}
+
+		log.Printf("Starting OpAMP WebSocket server on port %s", opampPort)
+		if err := s.wsServer.Start(settings); err != nil {
+			log.Fatalf("Failed to start OpAMP WebSocket server: %v", err)
+		}
+	}()
+}
+
+// attachOpAMPHTTP mounts the OpAMP plain-HTTP (long-poll) transport onto the
+// REST API mux at /v1/opamp, so collectors configured with an HTTP OpAMP
+// client can connect without a dedicated port.
+func (s *OpAMPServer) attachOpAMPHTTP(mux *http.ServeMux) {
+	s.httpServer = server.New(nil)
+
+	handler, connContext, err := s.httpServer.Attach(server.Settings{Callbacks: s.opampCallbacks()})
+	if err != nil {
+		log.Fatalf("Failed to attach OpAMP HTTP transport: %v", err)
+	}
+	// connContext must be set as the ConnContext of the http.Server that
+	// ultimately serves mux, so the OpAMP HTTP transport can recover the
+	// underlying net.Conn; main() wires it in once that server exists.
+	s.opampConnContext = connContext
+
+	log.Printf("Exposing OpAMP HTTP transport at /v1/opamp")
+	mux.HandleFunc("/v1/opamp", handler)
+}